@@ -0,0 +1,91 @@
+package gospritesheet
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"io"
+	"math/rand"
+)
+
+// AnimateEffect generates a deterministic sequence of frames for the
+// flame, drip, glow, or corrosion effect selected by cfg.Kind, using
+// cfg.ColorA/cfg.ColorB (or the Corrosion* fields for EffectCorrosion) and
+// cfg.Parallelism for every frame.
+//
+// Each frame gets its own *rand.Rand seeded from seed+frameIndex (see
+// EffectConfig.Rand), instead of reseeding the global math/rand source, so
+// frames never share random state: the same seed and cfg.Parallelism
+// always reproduce the same animation (a different Parallelism splits the
+// random draws differently and gives a different one), and generating two
+// animations concurrently can't corrupt either one's determinism.
+func AnimateEffect(layer image.Image, cfg EffectConfig, frames int, seed int64) []*image.RGBA {
+	out := make([]*image.RGBA, frames)
+
+	for i := 0; i < frames; i++ {
+		opts := []EffectOption{
+			WithParallelism(cfg.Parallelism),
+			WithRand(rand.New(rand.NewSource(seed + int64(i)))),
+		}
+
+		switch cfg.Kind {
+		case EffectDrip:
+			out[i] = ApplyDripEffect(layer, cfg.ColorA, cfg.ColorB, opts...)
+		case EffectGlow:
+			out[i] = ApplyGlowEffect(layer, cfg.ColorA, cfg.ColorB, opts...)
+		case EffectCorrosion:
+			out[i] = ApplyCorrosion(layer, cfg.CorrosionColor, cfg.CorrosionIterations, cfg.CorrosionSeeds, opts...)
+		default:
+			out[i] = ApplyFlameEffect(layer, cfg.ColorA, cfg.ColorB, opts...)
+		}
+	}
+
+	return out
+}
+
+// Quantizer dithers an RGBA image onto a paletted destination. The standard
+// library's draw.FloydSteinberg satisfies this directly.
+type Quantizer = draw.Drawer
+
+// EncodeGIF quantizes frames to a single shared palette of at most 256
+// colors (built with the same median-cut quantization ExtractPalette uses,
+// over every frame's pixels combined so the animation doesn't flicker
+// between per-frame palettes) and writes them as an animated GIF via
+// image/gif. quantizer dithers each frame onto its paletted destination;
+// pass draw.FloydSteinberg for the standard library's error-diffusion
+// dither.
+func EncodeGIF(w io.Writer, frames []*image.RGBA, delayCentiseconds int, quantizer Quantizer) error {
+	if len(frames) == 0 {
+		return nil
+	}
+
+	palette := sharedPalette(frames, 256)
+
+	anim := gif.GIF{}
+	for _, frame := range frames {
+		bounds := frame.Bounds()
+		paletted := image.NewPaletted(bounds, palette)
+		quantizer.Draw(paletted, bounds, frame, bounds.Min)
+
+		anim.Image = append(anim.Image, paletted)
+		anim.Delay = append(anim.Delay, delayCentiseconds)
+	}
+
+	return gif.EncodeAll(w, &anim)
+}
+
+// sharedPalette runs median-cut quantization over the combined pixels of
+// every frame, so all frames in an animation share one palette.
+func sharedPalette(frames []*image.RGBA, maxColors int) color.Palette {
+	var points []color.RGBA
+	for _, frame := range frames {
+		bounds := frame.Bounds()
+		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+			for x := bounds.Min.X; x < bounds.Max.X; x++ {
+				points = append(points, color.RGBAModel.Convert(frame.At(x, y)).(color.RGBA))
+			}
+		}
+	}
+	return medianCutPalette(points, maxColors)
+}
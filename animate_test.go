@@ -0,0 +1,52 @@
+package gospritesheet
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"testing"
+)
+
+// TestEncodeGIFProducesDecodableAnimation checks that EncodeGIF writes an
+// animated GIF with one frame per input image and the requested delay,
+// round-tripping it through image/gif's own decoder.
+func TestEncodeGIFProducesDecodableAnimation(t *testing.T) {
+	frames := []*image.RGBA{
+		filledSquare(4, 2),
+		filledSquare(4, 4),
+	}
+	frames[1].Set(0, 0, color.RGBA{R: 0xff, A: 0xff})
+
+	var buf bytes.Buffer
+	if err := EncodeGIF(&buf, frames, 5, draw.FloydSteinberg); err != nil {
+		t.Fatalf("EncodeGIF returned error: %v", err)
+	}
+
+	anim, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatalf("gif.DecodeAll failed on EncodeGIF output: %v", err)
+	}
+
+	if len(anim.Image) != len(frames) {
+		t.Fatalf("decoded %d frames, want %d", len(anim.Image), len(frames))
+	}
+	for i, delay := range anim.Delay {
+		if delay != 5 {
+			t.Errorf("frame %d delay = %d, want 5", i, delay)
+		}
+	}
+}
+
+// TestEncodeGIFEmptyFrames checks that EncodeGIF is a no-op, not an error,
+// when given no frames.
+func TestEncodeGIFEmptyFrames(t *testing.T) {
+	var buf bytes.Buffer
+	if err := EncodeGIF(&buf, nil, 5, draw.FloydSteinberg); err != nil {
+		t.Fatalf("EncodeGIF(nil frames) returned error: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("EncodeGIF(nil frames) wrote %d bytes, want 0", buf.Len())
+	}
+}
@@ -0,0 +1,85 @@
+package gospritesheet
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// NewLinearGradient builds a gradient of steps colors by interpolating
+// linearly across stops. With exactly two stops this is equivalent to
+// sampling interpolateColor at steps evenly spaced percentages; with more
+// stops the gradient is split into even segments between each consecutive
+// pair. At least two stops are required for interpolation; fewer just
+// fills the gradient with the single stop given (or, with no stops, leaves
+// all steps entries nil).
+func NewLinearGradient(steps int, stops ...color.Color) []color.Color {
+	gradient := make([]color.Color, steps)
+	if len(stops) == 0 || steps < 1 {
+		return gradient
+	}
+	if len(stops) == 1 {
+		for i := range gradient {
+			gradient[i] = stops[0]
+		}
+		return gradient
+	}
+
+	segments := len(stops) - 1
+	for i := 0; i < steps; i++ {
+		t := 0.0
+		if steps > 1 {
+			t = float64(i) / float64(steps-1)
+		}
+
+		segment := t * float64(segments)
+		segIndex := int(segment)
+		if segIndex >= segments {
+			segIndex = segments - 1
+		}
+		segPercentage := segment - float64(segIndex)
+
+		gradient[i] = interpolateColor(stops[segIndex], stops[segIndex+1], segPercentage)
+	}
+	return gradient
+}
+
+// ApplyGradientTint tints layer using gradient, indexed by each pixel's
+// alpha: opaque pixels map towards the first color in gradient, pixels
+// with only a sliver of alpha map towards the last. It is a standalone
+// effect alongside ApplyGlowEffect, so callers can produce damage flashes, freeze
+// overlays, or heatmap-style variants of a sprite without writing a
+// per-pixel loop themselves. opacity controls how strongly the gradient
+// color is blended over the source pixel, where 0xff fully replaces it.
+func ApplyGradientTint(layer image.Image, gradient []color.Color, opacity uint8) *image.RGBA {
+	bounds := layer.Bounds()
+	tinted := image.NewRGBA(bounds)
+	draw.Draw(tinted, bounds, layer, bounds.Min, draw.Src)
+
+	if len(gradient) == 0 {
+		return tinted
+	}
+
+	intensity := float64(opacity) / 0xff
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			_, _, _, a := layer.At(x, y).RGBA()
+			if a == 0 {
+				continue
+			}
+
+			percent := float64(a) / float64(0xffff)
+			index := int(float64(len(gradient)-1) * (1 - percent))
+			if index < 0 {
+				index = 0
+			} else if index > len(gradient)-1 {
+				index = len(gradient) - 1
+			}
+
+			tinted.Set(x, y, blendColors(tinted.At(x, y), gradient[index], intensity))
+		}
+	}
+
+	return tinted
+}
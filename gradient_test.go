@@ -0,0 +1,70 @@
+package gospritesheet
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+// TestNewLinearGradientEndpoints checks that the first and last steps of a
+// gradient are exactly the given stops, regardless of how many steps are
+// requested in between.
+func TestNewLinearGradientEndpoints(t *testing.T) {
+	red := color.RGBA{R: 0xff, A: 0xff}
+	blue := color.RGBA{B: 0xff, A: 0xff}
+
+	gradient := NewLinearGradient(5, red, blue)
+	if len(gradient) != 5 {
+		t.Fatalf("len(gradient) = %d, want 5", len(gradient))
+	}
+	if gradient[0] != color.Color(red) {
+		t.Errorf("gradient[0] = %v, want %v", gradient[0], red)
+	}
+	if gradient[len(gradient)-1] != color.Color(blue) {
+		t.Errorf("gradient[last] = %v, want %v", gradient[len(gradient)-1], blue)
+	}
+}
+
+// TestNewLinearGradientSingleStop checks that a single stop fills the whole
+// gradient instead of interpolating.
+func TestNewLinearGradientSingleStop(t *testing.T) {
+	red := color.RGBA{R: 0xff, A: 0xff}
+	gradient := NewLinearGradient(3, red)
+	for i, c := range gradient {
+		if c != color.Color(red) {
+			t.Errorf("gradient[%d] = %v, want %v", i, c, red)
+		}
+	}
+}
+
+// TestApplyGradientTintMapsOpaqueToFirstStop checks that a fully opaque
+// pixel is tinted towards gradient[0], matching the corrected doc comment
+// and the formula's actual index = (len-1)*(1-percent) behavior.
+func TestApplyGradientTintMapsOpaqueToFirstStop(t *testing.T) {
+	first := color.RGBA{R: 0xff, A: 0xff}
+	last := color.RGBA{B: 0xff, A: 0xff}
+	gradient := []color.Color{first, last}
+
+	layer := image.NewRGBA(image.Rect(0, 0, 1, 1))
+	layer.Set(0, 0, color.RGBA{A: 0xff})
+
+	tinted := ApplyGradientTint(layer, gradient, 0xff)
+
+	got := color.RGBAModel.Convert(tinted.At(0, 0)).(color.RGBA)
+	if got.R != first.R || got.B != first.B {
+		t.Errorf("fully opaque pixel tinted to %v, want it to fully take on the first stop %v", got, first)
+	}
+}
+
+// TestApplyGradientTintSkipsTransparentPixels checks that fully transparent
+// pixels are left untouched.
+func TestApplyGradientTintSkipsTransparentPixels(t *testing.T) {
+	gradient := []color.Color{color.RGBA{R: 0xff, A: 0xff}, color.RGBA{B: 0xff, A: 0xff}}
+
+	layer := image.NewRGBA(image.Rect(0, 0, 1, 1))
+	tinted := ApplyGradientTint(layer, gradient, 0xff)
+
+	if tinted.At(0, 0) != (color.RGBA{}) {
+		t.Errorf("transparent pixel = %v, want untouched zero value", tinted.At(0, 0))
+	}
+}
@@ -0,0 +1,217 @@
+package gospritesheet
+
+import (
+	"image"
+	"image/color"
+	"sort"
+)
+
+// ColorMap maps specific colors to their replacement when recoloring a
+// Spritesheet via RemapColors. Colors that aren't present in Colors fall
+// back to the nearest match in Fallback, if set.
+type ColorMap struct {
+	Colors   map[color.Color]color.Color
+	Fallback color.Palette
+}
+
+// NewColorMap returns an empty ColorMap ready for use.
+func NewColorMap() ColorMap {
+	return ColorMap{Colors: make(map[color.Color]color.Color)}
+}
+
+// Set maps from to to. from is converted through color.RGBAModel first so
+// lookups are reliable regardless of the input image's color model (e.g.
+// NRGBA or premultiplied colors); to is stored as given.
+func (cm ColorMap) Set(from, to color.Color) {
+	cm.Colors[color.RGBAModel.Convert(from)] = to
+}
+
+// At returns the color c maps to: an exact match in Colors if there is one,
+// otherwise the nearest color in Fallback, otherwise c itself unchanged.
+func (cm ColorMap) At(c color.Color) color.Color {
+	if to, ok := cm.Colors[color.RGBAModel.Convert(c)]; ok {
+		return to
+	}
+	if cm.Fallback != nil {
+		return cm.Fallback.Convert(c)
+	}
+	return c
+}
+
+// RemapColors returns a new Spritesheet with every pixel recolored
+// according to cm in a single pass. Both the source pixels and the
+// ColorMap's keys are compared through color.RGBAModel.Convert, so the
+// match is reliable regardless of whether the underlying image decoded to
+// NRGBA, RGBA, or something else entirely.
+func (s *Spritesheet) RemapColors(cm ColorMap) *Spritesheet {
+	// Keys may have been written to Colors directly instead of through Set,
+	// so convert them here too.
+	colors := make(map[color.Color]color.Color, len(cm.Colors))
+	for from, to := range cm.Colors {
+		colors[color.RGBAModel.Convert(from)] = to
+	}
+	cm.Colors = colors
+
+	bounds := s.image.Bounds()
+	newImg := image.NewRGBA(bounds)
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			newImg.Set(x, y, cm.At(s.image.At(x, y)))
+		}
+	}
+
+	remapped := *s
+	remapped.image = newImg
+	return &remapped
+}
+
+// colorBox is an axis-aligned bounding box over a set of color points, used
+// by the median-cut quantizer in ExtractPalette.
+type colorBox struct {
+	points []color.RGBA
+}
+
+// channelRange returns, for each of the R, G, B channels, the spread
+// (max-min) of that channel across the box's points.
+func (b colorBox) channelRange() (rRange, gRange, bRange uint8) {
+	if len(b.points) == 0 {
+		return 0, 0, 0
+	}
+
+	minR, maxR := b.points[0].R, b.points[0].R
+	minG, maxG := b.points[0].G, b.points[0].G
+	minB, maxB := b.points[0].B, b.points[0].B
+	for _, p := range b.points[1:] {
+		if p.R < minR {
+			minR = p.R
+		}
+		if p.R > maxR {
+			maxR = p.R
+		}
+		if p.G < minG {
+			minG = p.G
+		}
+		if p.G > maxG {
+			maxG = p.G
+		}
+		if p.B < minB {
+			minB = p.B
+		}
+		if p.B > maxB {
+			maxB = p.B
+		}
+	}
+
+	return maxR - minR, maxG - minG, maxB - minB
+}
+
+// widestChannel returns the index (0=R, 1=G, 2=B) of the channel with the
+// greatest spread in the box, and that spread.
+func (b colorBox) widestChannel() (channel int, spread uint8) {
+	rRange, gRange, bRange := b.channelRange()
+
+	channel, spread = 0, rRange
+	if gRange > spread {
+		channel, spread = 1, gRange
+	}
+	if bRange > spread {
+		channel, spread = 2, bRange
+	}
+	return channel, spread
+}
+
+// split divides the box in two along the median of its widest channel.
+func (b colorBox) split() (colorBox, colorBox) {
+	channel, _ := b.widestChannel()
+
+	points := make([]color.RGBA, len(b.points))
+	copy(points, b.points)
+
+	sort.Slice(points, func(i, j int) bool {
+		switch channel {
+		case 0:
+			return points[i].R < points[j].R
+		case 1:
+			return points[i].G < points[j].G
+		default:
+			return points[i].B < points[j].B
+		}
+	})
+
+	mid := len(points) / 2
+	return colorBox{points: points[:mid]}, colorBox{points: points[mid:]}
+}
+
+// average returns the mean color of all points in the box.
+func (b colorBox) average() color.Color {
+	if len(b.points) == 0 {
+		return color.RGBA{A: 0xff}
+	}
+
+	var r, g, bl, a int
+	for _, p := range b.points {
+		r += int(p.R)
+		g += int(p.G)
+		bl += int(p.B)
+		a += int(p.A)
+	}
+	n := len(b.points)
+
+	return color.RGBA{
+		R: uint8(r / n),
+		G: uint8(g / n),
+		B: uint8(bl / n),
+		A: uint8(a / n),
+	}
+}
+
+// ExtractPalette extracts a palette of at most maxColors colors from the
+// spritesheet using median-cut quantization. This lets callers build a
+// ColorMap from an existing sheet instead of hand-picking hex codes.
+func (s *Spritesheet) ExtractPalette(maxColors int) color.Palette {
+	bounds := s.image.Bounds()
+	points := make([]color.RGBA, 0, bounds.Dx()*bounds.Dy())
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			points = append(points, color.RGBAModel.Convert(s.image.At(x, y)).(color.RGBA))
+		}
+	}
+	return medianCutPalette(points, maxColors)
+}
+
+// medianCutPalette reduces points to at most maxColors colors: starting
+// from a single box containing every point, the box with the widest
+// channel is repeatedly split at its median until maxColors boxes remain
+// (or no box can usefully be split further), and each box is reduced to
+// its average color.
+func medianCutPalette(points []color.RGBA, maxColors int) color.Palette {
+	if maxColors < 1 {
+		return nil
+	}
+
+	boxes := []colorBox{{points: points}}
+	for len(boxes) < maxColors {
+		splitIndex, widestSpread := -1, uint8(0)
+		for i, b := range boxes {
+			if len(b.points) < 2 {
+				continue
+			}
+			if _, spread := b.widestChannel(); spread > widestSpread {
+				splitIndex, widestSpread = i, spread
+			}
+		}
+		if splitIndex == -1 {
+			break
+		}
+
+		a, b := boxes[splitIndex].split()
+		boxes = append(boxes[:splitIndex], append([]colorBox{a, b}, boxes[splitIndex+1:]...)...)
+	}
+
+	palette := make(color.Palette, len(boxes))
+	for i, b := range boxes {
+		palette[i] = b.average()
+	}
+	return palette
+}
@@ -0,0 +1,94 @@
+package gospritesheet
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+// TestRemapColorsExactMatch checks that RemapColors replaces every pixel
+// matching a ColorMap entry and leaves everything else untouched.
+func TestRemapColorsExactMatch(t *testing.T) {
+	red := color.RGBA{R: 0xff, A: 0xff}
+	green := color.RGBA{G: 0xff, A: 0xff}
+	blue := color.RGBA{B: 0xff, A: 0xff}
+
+	img := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	img.Set(0, 0, red)
+	img.Set(1, 0, blue)
+	sheet := &Spritesheet{image: img, tileSize: 1, xCount: 2, yCount: 1}
+
+	cm := NewColorMap()
+	cm.Set(red, green)
+
+	remapped := sheet.RemapColors(cm)
+
+	if c := color.RGBAModel.Convert(remapped.image.At(0, 0)); c != color.Color(green) {
+		t.Errorf("remapped (0,0) = %v, want %v", c, green)
+	}
+	if c := color.RGBAModel.Convert(remapped.image.At(1, 0)); c != color.Color(blue) {
+		t.Errorf("remapped (1,0) = %v, want %v (unmapped colors must pass through unchanged)", c, blue)
+	}
+}
+
+// TestRemapColorsFallback checks that colors with no exact entry in Colors
+// fall back to the nearest match in Fallback.
+func TestRemapColorsFallback(t *testing.T) {
+	near := color.RGBA{R: 0xf0, A: 0xff}
+	want := color.RGBA{R: 0xff, A: 0xff}
+
+	img := image.NewRGBA(image.Rect(0, 0, 1, 1))
+	img.Set(0, 0, near)
+	sheet := &Spritesheet{image: img, tileSize: 1, xCount: 1, yCount: 1}
+
+	cm := NewColorMap()
+	cm.Fallback = color.Palette{want, color.RGBA{B: 0xff, A: 0xff}}
+
+	remapped := sheet.RemapColors(cm)
+	if c := color.RGBAModel.Convert(remapped.image.At(0, 0)); c != color.Color(want) {
+		t.Errorf("remapped (0,0) = %v, want nearest fallback color %v", c, want)
+	}
+}
+
+// TestExtractPaletteReducesToRequestedSize checks that ExtractPalette never
+// returns more than maxColors entries, even when the source has more
+// distinct colors than that.
+func TestExtractPaletteReducesToRequestedSize(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x * 60), G: uint8(y * 60), B: 0x80, A: 0xff})
+		}
+	}
+	sheet := &Spritesheet{image: img, tileSize: 1, xCount: 4, yCount: 4}
+
+	palette := sheet.ExtractPalette(4)
+	if len(palette) > 4 {
+		t.Fatalf("ExtractPalette(4) returned %d colors, want at most 4", len(palette))
+	}
+	if len(palette) == 0 {
+		t.Fatalf("ExtractPalette(4) returned no colors")
+	}
+}
+
+// TestMedianCutPaletteSeparatesDistinctClusters checks that two
+// well-separated clusters of points end up mapped to two different palette
+// entries rather than being averaged together.
+func TestMedianCutPaletteSeparatesDistinctClusters(t *testing.T) {
+	var points []color.RGBA
+	for i := 0; i < 10; i++ {
+		points = append(points, color.RGBA{R: 0x00, A: 0xff})
+		points = append(points, color.RGBA{R: 0xff, A: 0xff})
+	}
+
+	palette := medianCutPalette(points, 2)
+	if len(palette) != 2 {
+		t.Fatalf("medianCutPalette(points, 2) returned %d colors, want 2", len(palette))
+	}
+
+	r0, _, _, _ := palette[0].RGBA()
+	r1, _, _, _ := palette[1].RGBA()
+	if r0 == r1 {
+		t.Fatalf("medianCutPalette collapsed two distinct clusters into identical colors: %v, %v", palette[0], palette[1])
+	}
+}
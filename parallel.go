@@ -0,0 +1,160 @@
+package gospritesheet
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math/rand"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// EffectKind selects which effect AnimateEffect generates frames for.
+type EffectKind int
+
+const (
+	EffectFlame EffectKind = iota
+	EffectDrip
+	EffectGlow
+	EffectCorrosion
+)
+
+// EffectConfig configures how the cellular-automata style effects
+// (ApplyGlowEffect, ApplyCorrosion, ApplyFlameEffect, ApplyDripEffect) split
+// their work across goroutines, and which effect and colors AnimateEffect
+// should generate frames for.
+type EffectConfig struct {
+	// Parallelism is the number of goroutines used to process column strips
+	// of the image in parallel. If left at zero, it defaults to
+	// runtime.NumCPU().
+	Parallelism int
+
+	// Kind selects which effect AnimateEffect generates frames for.
+	// Defaults to EffectFlame.
+	Kind EffectKind
+
+	// ColorA and ColorB are the gradient endpoints used by the flame, drip,
+	// and glow effects.
+	ColorA, ColorB color.Color
+
+	// CorrosionColor, CorrosionIterations, and CorrosionSeeds configure
+	// ApplyCorrosion when Kind is EffectCorrosion.
+	CorrosionColor      color.Color
+	CorrosionIterations int
+	CorrosionSeeds      int
+
+	// Rand is the random source the effect draws from. If left nil, it
+	// defaults to a source seeded from the current time. Effects never
+	// read a shared *rand.Rand from more than one goroutine at once: when
+	// the work is split into several column strips, each strip gets its own
+	// child generator derived from Rand, and a single strip uses Rand
+	// itself (see forEachColumnStrip). Supplying a seeded Rand therefore
+	// makes an effect's output reproducible for a given Parallelism
+	// regardless of goroutine scheduling; a different Parallelism gives a
+	// different output.
+	Rand *rand.Rand
+}
+
+// EffectOption configures an EffectConfig.
+type EffectOption func(*EffectConfig)
+
+// WithParallelism caps the number of goroutines used to process an effect.
+func WithParallelism(n int) EffectOption {
+	return func(c *EffectConfig) {
+		c.Parallelism = n
+	}
+}
+
+// WithRand supplies the random source an effect draws from, making its
+// output reproducible for a given seed and Parallelism.
+func WithRand(rnd *rand.Rand) EffectOption {
+	return func(c *EffectConfig) {
+		c.Rand = rnd
+	}
+}
+
+// newEffectConfig builds an EffectConfig from the given options, defaulting
+// Parallelism to runtime.NumCPU() and Rand to a time-seeded source if they
+// weren't set.
+func newEffectConfig(opts ...EffectOption) *EffectConfig {
+	cfg := &EffectConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.Parallelism < 1 {
+		cfg.Parallelism = runtime.NumCPU()
+	}
+	if cfg.Rand == nil {
+		cfg.Rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return cfg
+}
+
+// forEachColumnStrip splits [0, width) into up to parallelism column strips
+// and calls fn once per strip, each on its own goroutine, blocking until all
+// strips have returned. Strips never overlap, so fn is free to write to its
+// own columns without locking as long as it only reads state that was
+// written in a previous pass (e.g. the "previous iteration" buffer of a
+// double-buffered cellular automaton).
+//
+// When there are several strips, rnd seeds one child *rand.Rand per strip,
+// derived sequentially before any goroutine starts (rnd itself is not safe
+// for concurrent use, but a generator seeded from one of its draws is
+// independent once created). A single strip runs on the calling goroutine
+// and is passed rnd itself.
+// Strip boundaries are a deterministic function of width and parallelism,
+// so for a given rnd this makes fn's random draws reproducible no matter
+// how the goroutines get scheduled.
+func forEachColumnStrip(width, parallelism int, rnd *rand.Rand, fn func(xStart, xEnd int, rnd *rand.Rand)) {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	if parallelism > width {
+		parallelism = width
+	}
+	if width <= 0 {
+		return
+	}
+	if parallelism <= 1 {
+		fn(0, width, rnd)
+		return
+	}
+
+	stripWidth := (width + parallelism - 1) / parallelism
+
+	type strip struct {
+		xStart, xEnd int
+		rnd          *rand.Rand
+	}
+	var strips []strip
+	for xStart := 0; xStart < width; xStart += stripWidth {
+		xEnd := xStart + stripWidth
+		if xEnd > width {
+			xEnd = width
+		}
+		strips = append(strips, strip{xStart, xEnd, rand.New(rand.NewSource(rnd.Int63()))})
+	}
+
+	var wg sync.WaitGroup
+	for _, s := range strips {
+		wg.Add(1)
+		go func(s strip) {
+			defer wg.Done()
+			fn(s.xStart, s.xEnd, s.rnd)
+		}(s)
+	}
+	wg.Wait()
+}
+
+// snapshotRGBA returns a copy of img that callers can safely read from while
+// concurrently writing to img itself, which is how the effect passes below
+// implement double buffering: every column strip reads the previous
+// iteration from the snapshot and writes the next one directly into the
+// live layer.
+func snapshotRGBA(img *image.RGBA) *image.RGBA {
+	bounds := img.Bounds()
+	snapshot := image.NewRGBA(bounds)
+	draw.Draw(snapshot, bounds, img, bounds.Min, draw.Src)
+	return snapshot
+}
@@ -69,25 +69,51 @@ func (s *Spritesheet) NumTiles() int {
 	return s.xCount * s.yCount
 }
 
-// TileImage returns an image.Image of the tile at the given index.
-// TODO: This should maybe take an image (and maybe offset) to draw on
-// instead of returning a new image. Also the color replacement could be
-// done here.
-func (s *Spritesheet) TileImage(index int) image.Image {
-	// Calculate the x and y position of the tile in the spritesheet
-	x := (index % s.xCount) * s.tileSize
-	y := (index / s.xCount) * s.tileSize
+// opaqueMask is a fully opaque mask shared by DrawTile and
+// DrawTileWithRemap, since neither needs per-pixel masking of its own.
+var opaqueMask = image.NewUniform(color.Alpha{A: 0xff})
+
+// tileBounds returns the source rectangle of the tile at the given index
+// within the underlying spritesheet image. The result is offset by the
+// image's own Bounds().Min, so a Spritesheet wrapping a sub-image (where
+// Min is not the origin) still addresses the right pixels.
+func (s *Spritesheet) tileBounds(index int) image.Rectangle {
+	min := s.image.Bounds().Min
+	x := min.X + (index%s.xCount)*s.tileSize
+	y := min.Y + (index/s.xCount)*s.tileSize
+	return image.Rect(x, y, x+s.tileSize, y+s.tileSize)
+}
 
-	// Create a new RGBA image for the tile
-	tile := image.NewRGBA(image.Rect(0, 0, s.tileSize, s.tileSize))
+// DrawTile draws the tile at the given index into dst at dp using op,
+// without allocating a fresh image for the tile first. This lets callers
+// compose sprites directly into an atlas or scene buffer with draw.Over or
+// draw.Src semantics.
+func (s *Spritesheet) DrawTile(dst draw.Image, dp image.Point, index int, op draw.Op) {
+	src := s.tileBounds(index)
+	dr := image.Rectangle{Min: dp, Max: dp.Add(src.Size())}
+	draw.DrawMask(dst, dr, s.image, src.Min, opaqueMask, image.Point{}, op)
+}
 
-	// Copy the tile from the spritesheet to the new image
-	for i := 0; i < s.tileSize; i++ {
-		for j := 0; j < s.tileSize; j++ {
-			tile.Set(i, j, s.image.At(x+i, y+j))
+// DrawTileWithRemap draws the tile at the given index into dst at dp like
+// DrawTile, but recolors it through cm first.
+func (s *Spritesheet) DrawTileWithRemap(dst draw.Image, dp image.Point, index int, cm ColorMap, op draw.Op) {
+	src := s.tileBounds(index)
+
+	remapped := image.NewRGBA(image.Rect(0, 0, src.Dx(), src.Dy()))
+	for y := 0; y < src.Dy(); y++ {
+		for x := 0; x < src.Dx(); x++ {
+			remapped.Set(x, y, cm.At(s.image.At(src.Min.X+x, src.Min.Y+y)))
 		}
 	}
 
+	dr := image.Rectangle{Min: dp, Max: dp.Add(src.Size())}
+	draw.DrawMask(dst, dr, remapped, image.Point{}, opaqueMask, image.Point{}, op)
+}
+
+// TileImage returns an image.Image of the tile at the given index.
+func (s *Spritesheet) TileImage(index int) image.Image {
+	tile := image.NewRGBA(image.Rect(0, 0, s.tileSize, s.tileSize))
+	s.DrawTile(tile, image.Point{}, index, draw.Src)
 	return tile
 }
 
@@ -115,18 +141,22 @@ const (
 )
 
 // applyEffect applies a generic effect (flame or drip) to the given layer.
-func applyEffect(layer image.Image, colorA, colorB color.Color, numColors int, direction int) *image.RGBA {
+//
+// Rows depend on the row before them (in the given direction) so they are
+// processed sequentially, but within a row the columns are split into
+// strips and processed by their own goroutine. Since a row is zeroed out
+// before it is processed, same-row effect-layer neighbors are treated as
+// unset (readers only ever see the previous row, i.e. the previous
+// iteration), which is what makes the column strips safe to run without
+// locking.
+func applyEffect(layer image.Image, colorA, colorB color.Color, numColors int, direction int, opts ...EffectOption) *image.RGBA {
 	const minNeighbors = 3
 
+	cfg := newEffectConfig(opts...)
 	bounds := layer.Bounds()
 	effectLayer := image.NewRGBA(bounds)
 
-	// Build a gradient index for all the colors we want to use.
-	gradient := make([]color.Color, numColors)
-	for i := 0; i < numColors; i++ {
-		percentage := float64(i) / float64(numColors-1)
-		gradient[i] = interpolateColor(colorA, colorB, percentage)
-	}
+	gradient := NewLinearGradient(numColors, colorA, colorB)
 
 	// Iterate over the pixels in the specified direction
 	startY := bounds.Max.Y - 1
@@ -134,190 +164,241 @@ func applyEffect(layer image.Image, colorA, colorB color.Color, numColors int, d
 		startY = bounds.Min.Y
 	}
 	for y := startY; y >= bounds.Min.Y && y < bounds.Max.Y; y -= direction {
-		for x := bounds.Min.X; x < bounds.Max.X; x++ {
-			// Get the color of the current pixel on the original layer
-			_, _, _, a := layer.At(x, y).RGBA()
-			_, _, _, aNeighbor := layer.At(x, y+direction).RGBA()
-			_, _, _, aEffectNeighbor := effectLayer.At(x, y+direction).RGBA()
+		forEachColumnStrip(bounds.Dx(), cfg.Parallelism, cfg.Rand, func(xStart, xEnd int, rnd *rand.Rand) {
+			for dx := xStart; dx < xEnd; dx++ {
+				x := bounds.Min.X + dx
 
-			// If the pixel is set on the original layer, we skip it since we don't want to cover it.
-			if a != 0 {
-				continue
-			}
+				// Get the color of the current pixel on the original layer
+				_, _, _, a := layer.At(x, y).RGBA()
+				_, _, _, aNeighbor := layer.At(x, y+direction).RGBA()
+				_, _, _, aEffectNeighbor := effectLayer.At(x, y+direction).RGBA()
 
-			// If the pixel above/below is set on the original layer, set the current pixel on the effect layer to the first color in the gradient
-			if aNeighbor != 0 {
-				effectLayer.Set(x, y, gradient[0])
-			} else if aEffectNeighbor != 0 {
-				// Check if at least two neighboring pixels are set on either layer.
-				// If not, skip the current pixel.
-				var numNeighbors int
-				for i := -1; i <= 1; i++ {
-					if x+i < bounds.Min.X || x+i >= bounds.Max.X {
-						continue
-					}
-					for j := -1; j <= 1; j++ {
-						if y+j < bounds.Min.Y || y+j >= bounds.Max.Y {
+				// If the pixel is set on the original layer, we skip it since we don't want to cover it.
+				if a != 0 {
+					continue
+				}
+
+				// If the pixel above/below is set on the original layer, set the current pixel on the effect layer to the first color in the gradient
+				if aNeighbor != 0 {
+					effectLayer.Set(x, y, gradient[0])
+				} else if aEffectNeighbor != 0 {
+					// Check if at least two neighboring pixels are set on either layer.
+					// If not, skip the current pixel.
+					var numNeighbors int
+					for i := -1; i <= 1; i++ {
+						if x+i < bounds.Min.X || x+i >= bounds.Max.X {
 							continue
 						}
+						for j := -1; j <= 1; j++ {
+							if y+j < bounds.Min.Y || y+j >= bounds.Max.Y {
+								continue
+							}
 
-						_, _, _, aLeft := layer.At(x+i, y+j).RGBA()
-						_, _, _, aEffectLeft := effectLayer.At(x+i, y+j).RGBA()
-						if aLeft != 0 || aEffectLeft != 0 {
-							numNeighbors++
+							_, _, _, aLeft := layer.At(x+i, y+j).RGBA()
+							// Same-row effect-layer reads are skipped: this row
+							// hasn't been written yet at the start of this pass,
+							// and other strips may be writing it concurrently.
+							var aEffectLeft uint32
+							if j != 0 {
+								_, _, _, aEffectLeft = effectLayer.At(x+i, y+j).RGBA()
+							}
+							if aLeft != 0 || aEffectLeft != 0 {
+								numNeighbors++
+							}
 						}
 					}
-				}
 
-				if (numNeighbors < minNeighbors && rand.Float64() < 0.5) || rand.Float64() < 0.2 {
-					continue
-				}
+					if (numNeighbors < minNeighbors && rnd.Float64() < 0.5) || rnd.Float64() < 0.2 {
+						continue
+					}
 
-				// Get the index of the current color in the gradient.
-				var index int
-				for i, c := range gradient {
-					if c == effectLayer.At(x, y+direction) {
-						index = i
-						break
+					// Get the index of the current color in the gradient.
+					var index int
+					for i, c := range gradient {
+						if c == effectLayer.At(x, y+direction) {
+							index = i
+							break
+						}
 					}
-				}
 
-				// Set the current pixel to the next color in the gradient
-				if index < numColors-1 {
-					effectLayer.Set(x, y, gradient[index+1])
+					// Set the current pixel to the next color in the gradient
+					if index < numColors-1 {
+						effectLayer.Set(x, y, gradient[index+1])
+					}
 				}
 			}
-		}
+		})
 	}
 
 	return effectLayer
 }
 
 // ApplyGlowEffect generates a glow effect for the given layer.
-func ApplyGlowEffect(layer image.Image, colorA, colorB color.Color) *image.RGBA {
-	// We iterate over all pixels in the layer, and initially set all unset neighbors to the first color in the gradient
-	// if the current pixel is set on the original layer.
-	// Then we iterate over all pixels again, and set each unset neighbor to the next color in the gradient if at least two
-	// neighboring pixels are set on either layer with a different color.
-	// NOTE: This could be optimized quite a bit.
-
+//
+// Each gradient color is one pass over the whole image. Every pass reads
+// neighbor state from a snapshot taken before the pass started (the
+// "previous iteration") and column strips of the pass run in their own
+// goroutine, each only ever writing to its own columns.
+func ApplyGlowEffect(layer image.Image, colorA, colorB color.Color, opts ...EffectOption) *image.RGBA {
 	const minNeighbors = 3
 	const numColors = 3
 
+	cfg := newEffectConfig(opts...)
 	bounds := layer.Bounds()
 	effectLayer := image.NewRGBA(bounds)
 
-	// Build a gradient index for all the colors we want to use.
-	gradient := make([]color.Color, numColors)
-	for i := 0; i < numColors; i++ {
-		percentage := float64(i) / float64(numColors-1)
-		gradient[i] = interpolateColor(colorA, colorB, percentage)
-	}
+	gradient := NewLinearGradient(numColors, colorA, colorB)
 
 	for colorIndex, c := range gradient {
-		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
-			for x := bounds.Min.X; x < bounds.Max.X; x++ {
-				// Get the color of the current pixel on the original layer
-				_, _, _, curPixelAlphaOrig := layer.At(x, y).RGBA()
-				// If we have colorIndex 0, we set all unset neighbors to the first color in the gradient
-				// if the current pixel is set on the original layer.
-				if colorIndex == 0 {
-					if curPixelAlphaOrig == 0 {
-						continue
-					}
-
-					// Iterate over all neighbors and set them to the first color in the gradient if they are unset
-					for i := -1; i <= 1; i++ {
-						if x+i < bounds.Min.X || x+i >= bounds.Max.X {
+		prev := snapshotRGBA(effectLayer)
+
+		if colorIndex == 0 {
+			forEachColumnStrip(bounds.Dx(), cfg.Parallelism, cfg.Rand, func(xStart, xEnd int, rnd *rand.Rand) {
+				for dx := xStart; dx < xEnd; dx++ {
+					x := bounds.Min.X + dx
+					for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+						// Set this pixel to the first gradient color if it is
+						// unset on the original layer but borders a pixel that
+						// is set, i.e. pull the seed color from a neighbor
+						// instead of pushing it onto one.
+						_, _, _, curPixelAlphaOrig := layer.At(x, y).RGBA()
+						if curPixelAlphaOrig != 0 {
 							continue
 						}
-						for j := -1; j <= 1; j++ {
-							if y+j < bounds.Min.Y || y+j >= bounds.Max.Y {
-								continue
-							}
 
-							_, _, _, aNeighbor := layer.At(x+i, y+j).RGBA()
-							if aNeighbor != 0 {
-								continue
-							}
-							_, _, _, aEffectNeighbor := effectLayer.At(x+i, y+j).RGBA()
-							if aEffectNeighbor == 0 {
-								effectLayer.Set(x+i, y+j, gradient[0])
-							}
+						if hasSetNeighbor(layer, bounds, x, y) {
+							effectLayer.Set(x, y, gradient[0])
 						}
 					}
-				} else if curPixelAlphaOrig == 0 {
-					// If the pixel is set on the original layer, we skip it since we don't want to cover it.
-
-					// Make sure that the current pixel is set on the effect layer and
-					// is not the current color (since we want to progress the gradient,
-					// not stay on the same color).
-					_, _, _, aEffect := effectLayer.At(x, y).RGBA()
-					effectCol := effectLayer.At(x, y)
-					if aEffect == 0 || effectCol == c {
+				}
+			})
+			continue
+		}
+
+		// A pixel "pushes" color c onto its unset neighbors if it already
+		// holds an earlier gradient color and enough pixels of its 3x3
+		// neighborhood (itself included) hold a gradient color other than
+		// c. We precompute which pixels qualify as
+		// pushers (reading only the previous iteration's snapshot, so this
+		// can run one column strip at a time) and then let every
+		// destination pixel pull from a qualifying neighbor instead of
+		// writing into its neighbors' columns, which is what keeps the
+		// strips safe to run without locking.
+		pushers := make([]bool, bounds.Dx()*bounds.Dy())
+		forEachColumnStrip(bounds.Dx(), cfg.Parallelism, cfg.Rand, func(xStart, xEnd int, rnd *rand.Rand) {
+			for dx := xStart; dx < xEnd; dx++ {
+				x := bounds.Min.X + dx
+				for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+					_, _, _, aEffect := prev.At(x, y).RGBA()
+					if aEffect == 0 || prev.At(x, y) == c {
 						continue
 					}
 
-					// Check if at least two neighboring pixels are set on the effect layer
-					// and are not the current color.
-					var numNeighbors int
-					for i := -1; i <= 1; i++ {
-						if x+i < bounds.Min.X || x+i >= bounds.Max.X {
-							continue
-						}
-						for j := -1; j <= 1; j++ {
-							if y+j < bounds.Min.Y || y+j >= bounds.Max.Y {
-								continue
-							}
-
-							// Make sure that the neighbor is set on the effect layer and
-							// is not the current color.
-							_, _, _, aEffectLeft := effectLayer.At(x+i, y+j).RGBA()
-							effectLeft := effectLayer.At(x+i, y+j)
-							if aEffectLeft != 0 && effectLeft != c {
-								numNeighbors++
-							}
-						}
+					if countGlowNeighbors(prev, bounds, x, y, c) < minNeighbors || rnd.Float64() < 0.1 {
+						continue
 					}
 
-					// If we don't have enough neighbors, we skip the current pixel.
-					if numNeighbors < minNeighbors || rand.Float64() < 0.1 {
+					pushers[(y-bounds.Min.Y)*bounds.Dx()+dx] = true
+				}
+			}
+		})
+
+		forEachColumnStrip(bounds.Dx(), cfg.Parallelism, cfg.Rand, func(xStart, xEnd int, rnd *rand.Rand) {
+			for dx := xStart; dx < xEnd; dx++ {
+				x := bounds.Min.X + dx
+				for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+					_, _, _, curPixelAlphaOrig := layer.At(x, y).RGBA()
+					if curPixelAlphaOrig != 0 {
 						continue
 					}
 
-					// Iterate over all neighbors and set them to the next color in the gradient if they are unset.
-					for i := -1; i <= 1; i++ {
-						if x+i < bounds.Min.X || x+i >= bounds.Max.X {
-							continue
-						}
-						for j := -1; j <= 1; j++ {
-							if y+j < bounds.Min.Y || y+j >= bounds.Max.Y {
-								continue
-							}
+					_, _, _, aEffect := prev.At(x, y).RGBA()
+					if aEffect != 0 {
+						continue
+					}
 
-							// Set the neighbor to the next color in the gradient if it is unset.
-							_, _, _, aEffectLeft := effectLayer.At(x+i, y+j).RGBA()
-							if aEffectLeft == 0 {
-								effectLayer.Set(x+i, y+j, c)
-							}
-						}
+					if hasPusherNeighbor(pushers, bounds, x, y) {
+						effectLayer.Set(x, y, c)
 					}
 				}
 			}
-		}
+		})
 	}
 
 	return effectLayer
 }
 
+// countGlowNeighbors counts the 3x3 neighbors of (x, y) that are set on
+// layer and hold a color other than c.
+func countGlowNeighbors(layer *image.RGBA, bounds image.Rectangle, x, y int, c color.Color) int {
+	var numNeighbors int
+	for i := -1; i <= 1; i++ {
+		if x+i < bounds.Min.X || x+i >= bounds.Max.X {
+			continue
+		}
+		for j := -1; j <= 1; j++ {
+			if y+j < bounds.Min.Y || y+j >= bounds.Max.Y {
+				continue
+			}
+
+			_, _, _, a := layer.At(x+i, y+j).RGBA()
+			if a != 0 && layer.At(x+i, y+j) != c {
+				numNeighbors++
+			}
+		}
+	}
+	return numNeighbors
+}
+
+// hasPusherNeighbor reports whether any of the 3x3 neighbors of (x, y) is
+// marked as a pusher in pushers, a flat bounds.Dx()*bounds.Dy() grid.
+func hasPusherNeighbor(pushers []bool, bounds image.Rectangle, x, y int) bool {
+	for i := -1; i <= 1; i++ {
+		if x+i < bounds.Min.X || x+i >= bounds.Max.X {
+			continue
+		}
+		for j := -1; j <= 1; j++ {
+			if y+j < bounds.Min.Y || y+j >= bounds.Max.Y {
+				continue
+			}
+
+			if pushers[(y+j-bounds.Min.Y)*bounds.Dx()+(x+i-bounds.Min.X)] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasSetNeighbor reports whether any of the 3x3 neighbors of (x, y) has a
+// non-zero alpha on layer.
+func hasSetNeighbor(layer image.Image, bounds image.Rectangle, x, y int) bool {
+	for i := -1; i <= 1; i++ {
+		if x+i < bounds.Min.X || x+i >= bounds.Max.X {
+			continue
+		}
+		for j := -1; j <= 1; j++ {
+			if y+j < bounds.Min.Y || y+j >= bounds.Max.Y {
+				continue
+			}
+
+			_, _, _, a := layer.At(x+i, y+j).RGBA()
+			if a != 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // ApplyFlameEffect generates a flame effect for the given layer.
-func ApplyFlameEffect(layer image.Image, colorA, colorB color.Color) *image.RGBA {
-	return applyEffect(layer, colorA, colorB, 10, DirectionUp)
+func ApplyFlameEffect(layer image.Image, colorA, colorB color.Color, opts ...EffectOption) *image.RGBA {
+	return applyEffect(layer, colorA, colorB, 10, DirectionUp, opts...)
 }
 
 // ApplyDripEffect generates a drip effect for the given layer.
-func ApplyDripEffect(layer image.Image, colorA, colorB color.Color) *image.RGBA {
-	return applyEffect(layer, colorA, colorB, 15, DirectionDown)
+func ApplyDripEffect(layer image.Image, colorA, colorB color.Color, opts ...EffectOption) *image.RGBA {
+	return applyEffect(layer, colorA, colorB, 15, DirectionDown, opts...)
 }
 
 // blendColors blends two colors with a given intensity (0.0 - 1.0).
@@ -339,7 +420,8 @@ func blendColors(colorA, colorB color.Color, intensity float64) color.Color {
 // TODO:
 // - Avoid eating into outlines.
 // - We could 'chip away' at the corners of the sprites to make them look more worn.
-func ApplyCorrosion(layer image.Image, color color.Color, numIterations, numSeeds int) *image.RGBA {
+func ApplyCorrosion(layer image.Image, color color.Color, numIterations, numSeeds int, opts ...EffectOption) *image.RGBA {
+	cfg := newEffectConfig(opts...)
 	bounds := layer.Bounds()
 
 	// We will use two bool slices to represent the corrosion layer at two states.
@@ -348,7 +430,7 @@ func ApplyCorrosion(layer image.Image, color color.Color, numIterations, numSeed
 
 	// Iterate over the pixels in the original layer using rand.Perm, and set
 	// the seed points for the corrosion layer if the pixel is set in the original layer.
-	for _, i := range rand.Perm(bounds.Dx() * bounds.Dy()) {
+	for _, i := range cfg.Rand.Perm(bounds.Dx() * bounds.Dy()) {
 		x := i % bounds.Dx()
 		y := i / bounds.Dx()
 
@@ -363,50 +445,56 @@ func ApplyCorrosion(layer image.Image, color color.Color, numIterations, numSeed
 		}
 	}
 
-	// Iterate over the number of iterations
+	// Iterate over the number of iterations. Each iteration only ever reads
+	// corrosionPrev (the previous iteration) and writes corrosionCur, so the
+	// columns within an iteration can be split into strips and processed by
+	// their own goroutine without locking.
 	for i := 0; i < numIterations; i++ {
-		// Iterate over all cells in the layer
-		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
-			for x := bounds.Min.X; x < bounds.Max.X; x++ {
-				// If the current cell is not set in the original layer, skip it.
-				// If there's nothing to corrode, we can skip the cell.
-				_, _, _, a := layer.At(x, y).RGBA()
-				if a == 0 {
-					continue
-				}
-
-				// Check if the current cell is set in the previous state.
-				// If so, copy it to the current state.
-				index := y*bounds.Dx() + x
-				if corrosionPrev[index] {
-					corrosionCur[index] = true
-					continue
-				}
+		forEachColumnStrip(bounds.Dx(), cfg.Parallelism, cfg.Rand, func(xStart, xEnd int, rnd *rand.Rand) {
+			for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+				for dx := xStart; dx < xEnd; dx++ {
+					x := bounds.Min.X + dx
+
+					// If the current cell is not set in the original layer, skip it.
+					// If there's nothing to corrode, we can skip the cell.
+					_, _, _, a := layer.At(x, y).RGBA()
+					if a == 0 {
+						continue
+					}
 
-				// Check if any of the neighbors are set in the previous state.
-				// The higher the number, the more likely the current cell will be set.
-				var numNeighbors int
-				for i := -1; i <= 1; i++ {
-					if x+i < bounds.Min.X || x+i >= bounds.Max.X {
+					// Check if the current cell is set in the previous state.
+					// If so, copy it to the current state.
+					index := y*bounds.Dx() + x
+					if corrosionPrev[index] {
+						corrosionCur[index] = true
 						continue
 					}
-					for j := -1; j <= 1; j++ {
-						if y+j < bounds.Min.Y || y+j >= bounds.Max.Y {
+
+					// Check if any of the neighbors are set in the previous state.
+					// The higher the number, the more likely the current cell will be set.
+					var numNeighbors int
+					for i := -1; i <= 1; i++ {
+						if x+i < bounds.Min.X || x+i >= bounds.Max.X {
 							continue
 						}
+						for j := -1; j <= 1; j++ {
+							if y+j < bounds.Min.Y || y+j >= bounds.Max.Y {
+								continue
+							}
 
-						if corrosionPrev[(y+j)*bounds.Dx()+x+i] {
-							numNeighbors++
+							if corrosionPrev[(y+j)*bounds.Dx()+x+i] {
+								numNeighbors++
+							}
 						}
 					}
-				}
 
-				// Set the current cell based on the number of neighbors
-				if rand.Intn(8) < numNeighbors {
-					corrosionCur[index] = true
+					// Set the current cell based on the number of neighbors
+					if rnd.Intn(8) < numNeighbors {
+						corrosionCur[index] = true
+					}
 				}
 			}
-		}
+		})
 
 		// Swap the current and previous corrosion states
 		corrosionCur, corrosionPrev = corrosionPrev, corrosionCur
@@ -0,0 +1,209 @@
+package gospritesheet
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// filledSquare returns a size x size fully transparent image with a
+// solid square of opaque black in the middle, square pixels wide/tall.
+func filledSquare(size, square int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	off := (size - square) / 2
+	for y := off; y < off+square; y++ {
+		for x := off; x < off+square; x++ {
+			img.Set(x, y, color.RGBA{A: 0xff})
+		}
+	}
+	return img
+}
+
+// countSet returns the number of pixels in img with non-zero alpha.
+func countSet(img *image.RGBA) int {
+	bounds := img.Bounds()
+	var n int
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if _, _, _, a := img.At(x, y).RGBA(); a != 0 {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+func seededRand(seed int64) *rand.Rand {
+	return rand.New(rand.NewSource(seed))
+}
+
+// subImageSheet returns a Spritesheet over a sub-image of a larger canvas,
+// offset by (marginX, marginY), with tiles numbered left-to-right,
+// top-to-bottom filled with solid colors from colors.
+func subImageSheet(tileSize, xCount, yCount, marginX, marginY int, colors []color.Color) *Spritesheet {
+	full := image.NewRGBA(image.Rect(0, 0, marginX+xCount*tileSize, marginY+yCount*tileSize))
+	sub := full.SubImage(image.Rect(marginX, marginY, full.Bounds().Max.X, full.Bounds().Max.Y))
+
+	for i, c := range colors {
+		x := marginX + (i%xCount)*tileSize
+		y := marginY + (i/xCount)*tileSize
+		for dy := 0; dy < tileSize; dy++ {
+			for dx := 0; dx < tileSize; dx++ {
+				full.Set(x+dx, y+dy, c)
+			}
+		}
+	}
+
+	return &Spritesheet{
+		image:    sub,
+		tileSize: tileSize,
+		xCount:   xCount,
+		yCount:   yCount,
+	}
+}
+
+// TestTileBoundsOffsetsBySubImageMin guards against a regression where
+// tileBounds ignored the underlying image's Bounds().Min, so a Spritesheet
+// wrapping a SubImage (a non-zero Min) sampled the wrong region entirely.
+func TestTileBoundsOffsetsBySubImageMin(t *testing.T) {
+	red := color.RGBA{R: 0xff, A: 0xff}
+	blue := color.RGBA{B: 0xff, A: 0xff}
+	sheet := subImageSheet(4, 2, 1, 10, 6, []color.Color{red, blue})
+
+	got := sheet.tileBounds(1)
+	want := image.Rect(10+4, 6, 10+8, 6+4)
+	if got != want {
+		t.Fatalf("tileBounds(1) = %v, want %v", got, want)
+	}
+
+	tile := sheet.TileImage(1)
+	if c := color.RGBAModel.Convert(tile.At(0, 0)); c != color.Color(blue) {
+		t.Fatalf("TileImage(1) top-left pixel = %v, want %v", c, blue)
+	}
+}
+
+// TestDrawTileOffsetsBySubImageMin exercises DrawTile directly (as opposed
+// to TileImage, which is built on top of it) against the same sub-image
+// scenario.
+func TestDrawTileOffsetsBySubImageMin(t *testing.T) {
+	red := color.RGBA{R: 0xff, A: 0xff}
+	blue := color.RGBA{B: 0xff, A: 0xff}
+	sheet := subImageSheet(4, 2, 1, 10, 6, []color.Color{red, blue})
+
+	dst := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	sheet.DrawTile(dst, image.Point{}, 1, draw.Src)
+
+	if c := color.RGBAModel.Convert(dst.At(0, 0)); c != color.Color(blue) {
+		t.Fatalf("DrawTile(1) top-left pixel = %v, want %v", c, blue)
+	}
+}
+
+// TestDrawTileWithRemap checks that DrawTileWithRemap both selects the
+// right source tile and recolors it through the given ColorMap.
+func TestDrawTileWithRemap(t *testing.T) {
+	red := color.RGBA{R: 0xff, A: 0xff}
+	sheet := subImageSheet(3, 1, 1, 0, 0, []color.Color{red})
+
+	cm := NewColorMap()
+	cm.Set(red, color.RGBA{G: 0xff, A: 0xff})
+
+	dst := image.NewRGBA(image.Rect(0, 0, 3, 3))
+	sheet.DrawTileWithRemap(dst, image.Point{}, 0, cm, draw.Src)
+
+	want := color.RGBA{G: 0xff, A: 0xff}
+	if c := color.RGBAModel.Convert(dst.At(1, 1)); c != color.Color(want) {
+		t.Fatalf("DrawTileWithRemap center pixel = %v, want %v", c, want)
+	}
+}
+
+// TestApplyGlowEffectGrowsBeyondFirstRing guards against a regression where
+// the colorIndex>0 pass stopped expanding the glow past the immediate
+// 1-pixel perimeter of the source shape (i.e. every gradient color past
+// the first rendered nothing new).
+func TestApplyGlowEffectGrowsBeyondFirstRing(t *testing.T) {
+	layer := filledSquare(15, 3)
+	glow := ApplyGlowEffect(layer, color.RGBA{R: 0xff, A: 0xff}, color.RGBA{B: 0xff, A: 0xff}, WithRand(seededRand(1)))
+
+	// The immediate ring around a 3x3 square is its 16-pixel perimeter.
+	// If the glow only ever re-colors that ring, something regressed the
+	// growth behavior past colorIndex 0.
+	const firstRingSize = 16
+	if got := countSet(glow); got <= firstRingSize {
+		t.Fatalf("ApplyGlowEffect produced %d set pixels, want more than the %d-pixel first ring (glow did not grow)", got, firstRingSize)
+	}
+}
+
+// TestApplyGlowEffectParallelismGrows checks that a higher Parallelism
+// doesn't collapse the glow back down to the first ring the way the
+// original push/pull regression did.
+func TestApplyGlowEffectParallelismGrows(t *testing.T) {
+	layer := filledSquare(20, 4)
+
+	for _, p := range []int{1, 2, 8} {
+		glow := ApplyGlowEffect(layer, color.RGBA{G: 0xff, A: 0xff}, color.RGBA{B: 0xff, A: 0xff}, WithParallelism(p), WithRand(seededRand(42)))
+		// The immediate ring around a 4x4 square is its 20-pixel perimeter.
+		if got := countSet(glow); got <= 20 {
+			t.Errorf("Parallelism(%d): ApplyGlowEffect produced %d set pixels, want more than the first ring", p, got)
+		}
+	}
+}
+
+// TestForEachColumnStrip checks that every column in [0, width) is visited
+// by exactly one strip, across a range of widths and parallelism values.
+// This is the invariant the whole parallel effect rewrite depends on: a
+// gap or overlap here would silently corrupt every effect built on top of
+// it.
+func TestForEachColumnStrip(t *testing.T) {
+	for _, width := range []int{0, 1, 3, 17, 64} {
+		for _, parallelism := range []int{1, 2, 3, 8, 100} {
+			seen := make([]int, width)
+			forEachColumnStrip(width, parallelism, seededRand(1), func(xStart, xEnd int, rnd *rand.Rand) {
+				for x := xStart; x < xEnd; x++ {
+					seen[x]++
+				}
+			})
+			for x, n := range seen {
+				if n != 1 {
+					t.Fatalf("width=%d parallelism=%d: column %d visited %d times, want 1", width, parallelism, x, n)
+				}
+			}
+		}
+	}
+}
+
+// TestAnimateEffectDeterministic checks that AnimateEffect with the same
+// seed and Parallelism reproduces byte-identical frames, and that it no
+// longer depends on mutating the global math/rand source: an unrelated
+// caller drawing from the global source between the two runs must not be
+// able to perturb the result the way the old rand.Seed-based
+// implementation could.
+func TestAnimateEffectDeterministic(t *testing.T) {
+	layer := filledSquare(12, 3)
+	cfg := EffectConfig{
+		Kind:        EffectGlow,
+		ColorA:      color.RGBA{R: 0xff, A: 0xff},
+		ColorB:      color.RGBA{B: 0xff, A: 0xff},
+		Parallelism: 6,
+	}
+
+	want := AnimateEffect(layer, cfg, 4, 7)
+
+	// An unrelated caller reseeding/drawing from the global source shouldn't
+	// be able to perturb AnimateEffect's output anymore.
+	rand.Seed(999)
+	rand.Float64()
+
+	got := AnimateEffect(layer, cfg, 4, 7)
+
+	if len(want) != len(got) {
+		t.Fatalf("got %d frames, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !reflect.DeepEqual(want[i].Pix, got[i].Pix) {
+			t.Errorf("frame %d differs between two identically-seeded AnimateEffect calls", i)
+		}
+	}
+}